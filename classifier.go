@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog/v2"
+)
+
+// assetClass is the result of classifying an asset's filename.
+type assetClass struct {
+	OS            string
+	Arch          string
+	PackageFormat string
+}
+
+const unknownClass = "unknown"
+
+// osHeuristics and archHeuristics are tried in order; the first match wins.
+var (
+	osHeuristics = []struct {
+		re *regexp.Regexp
+		os string
+	}{
+		{regexp.MustCompile(`(?i)linux`), "linux"},
+		{regexp.MustCompile(`(?i)darwin|macos|osx`), "darwin"},
+		{regexp.MustCompile(`(?i)windows|\bwin(32|64)?\b`), "windows"},
+	}
+
+	archHeuristics = []struct {
+		re   *regexp.Regexp
+		arch string
+	}{
+		{regexp.MustCompile(`(?i)amd64|x86[_-]?64|x64`), "amd64"},
+		{regexp.MustCompile(`(?i)arm64|aarch64`), "arm64"},
+		{regexp.MustCompile(`(?i)armv?7|armhf|\barm\b`), "arm"},
+		{regexp.MustCompile(`(?i)386|i386|i686|x86(?:[_-]?32)?`), "386"},
+	}
+
+	formatRe = regexp.MustCompile(`(?i)\.(tar\.gz|tgz|tar\.xz|zip|deb|rpm|apk|dmg|exe|msi)$`)
+)
+
+// classifierOverride special-cases a filename pattern the built-in heuristics get wrong.
+type classifierOverride struct {
+	Pattern       string `yaml:"pattern"`
+	OS            string `yaml:"os"`
+	Arch          string `yaml:"arch"`
+	PackageFormat string `yaml:"package_format"`
+	re            *regexp.Regexp
+}
+
+// classifierConfig is the contents of --classifier-config.
+type classifierConfig struct {
+	Overrides []classifierOverride `yaml:"overrides"`
+}
+
+// loadClassifierConfig reads and compiles the overrides at path. An empty path yields an
+// empty config, relying entirely on the built-in heuristics.
+func loadClassifierConfig(path string) (*classifierConfig, error) {
+	if path == "" {
+		return &classifierConfig{}, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg classifierConfig
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for i, o := range cfg.Overrides {
+		re, err := regexp.Compile(o.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("override %d pattern %q: %w", i, o.Pattern, err)
+		}
+		cfg.Overrides[i].re = re
+	}
+
+	return &cfg, nil
+}
+
+// classify determines {OS, Arch, PackageFormat} for an asset filename, consulting the
+// config's overrides before falling back to the built-in regex heuristics. Any field the
+// heuristics can't determine falls back to "unknown", and the filename is logged via klog
+// so users can extend --classifier-config to cover it.
+func (cfg *classifierConfig) classify(name string) assetClass {
+	for _, o := range cfg.Overrides {
+		if o.re.MatchString(name) {
+			return assetClass{OS: o.OS, Arch: o.Arch, PackageFormat: o.PackageFormat}
+		}
+	}
+
+	c := assetClass{OS: unknownClass, Arch: unknownClass, PackageFormat: unknownClass}
+
+	for _, h := range osHeuristics {
+		if h.re.MatchString(name) {
+			c.OS = h.os
+			break
+		}
+	}
+	for _, h := range archHeuristics {
+		if h.re.MatchString(name) {
+			c.Arch = h.arch
+			break
+		}
+	}
+	if m := formatRe.FindStringSubmatch(name); m != nil {
+		c.PackageFormat = strings.ToLower(m[1])
+	}
+
+	if c.OS == unknownClass || c.Arch == unknownClass || c.PackageFormat == unknownClass {
+		klog.Infof("unclassified asset %q: %+v", name, c)
+	}
+
+	return c
+}
+
+// classifyAndAggregate classifies every asset in rs's non-prerelease releases and attaches
+// the resulting overlay-wide DownloadsByOS/Arch/Format totals to the latest release, the
+// only one the html renderer reads them from. Attaching the same aggregate to every release
+// would make the json renderer's per-release dump repeat one overlay-wide total under a
+// field name that reads as per-release.
+func classifyAndAggregate(cfg *classifierConfig, rs []*release) {
+	byOS := map[string]int{}
+	byArch := map[string]int{}
+	byFormat := map[string]int{}
+
+	for _, r := range rs {
+		if r.Prerelease {
+			continue
+		}
+		for name, count := range r.Downloads {
+			c := cfg.classify(name)
+			byOS[c.OS] += count
+			byArch[c.Arch] += count
+			byFormat[c.PackageFormat] += count
+		}
+	}
+
+	latest := latestRelease(rs)
+	if latest == nil {
+		return
+	}
+	latest.DownloadsByOS = byOS
+	latest.DownloadsByArch = byArch
+	latest.DownloadsByFormat = byFormat
+}
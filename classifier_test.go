@@ -0,0 +1,53 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	cfg := &classifierConfig{}
+
+	tests := []struct {
+		name string
+		want assetClass
+	}{
+		{"tool_linux_amd64.tar.gz", assetClass{OS: "linux", Arch: "amd64", PackageFormat: "tar.gz"}},
+		{"tool_darwin_arm64.tar.gz", assetClass{OS: "darwin", Arch: "arm64", PackageFormat: "tar.gz"}},
+		{"tool-windows-x64.zip", assetClass{OS: "windows", Arch: "amd64", PackageFormat: "zip"}},
+		{"tool_linux_386.deb", assetClass{OS: "linux", Arch: "386", PackageFormat: "deb"}},
+		{"tool.rpm", assetClass{OS: unknownClass, Arch: unknownClass, PackageFormat: "rpm"}},
+		{"checksums.txt", assetClass{OS: unknownClass, Arch: unknownClass, PackageFormat: unknownClass}},
+	}
+
+	for _, tt := range tests {
+		got := cfg.classify(tt.name)
+		if got != tt.want {
+			t.Errorf("classify(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyOverride(t *testing.T) {
+	cfg := &classifierConfig{
+		Overrides: []classifierOverride{
+			{Pattern: `^special-bundle\.bin$`, OS: "embedded", Arch: "mips", PackageFormat: "raw"},
+		},
+	}
+	for i, o := range cfg.Overrides {
+		cfg.Overrides[i].re = regexp.MustCompile(o.Pattern)
+	}
+
+	got := cfg.classify("special-bundle.bin")
+	want := assetClass{OS: "embedded", Arch: "mips", PackageFormat: "raw"}
+	if got != want {
+		t.Errorf("classify with override = %+v, want %+v", got, want)
+	}
+
+	// An unrelated filename should still fall back to the built-in heuristics.
+	got = cfg.classify("tool_linux_amd64.zip")
+	want = assetClass{OS: "linux", Arch: "amd64", PackageFormat: "zip"}
+	if got != want {
+		t.Errorf("classify without override match = %+v, want %+v", got, want)
+	}
+}
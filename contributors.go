@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Contributor represents one user's activity within a release window.
+//
+// Additions and Deletions are only populated by sources that expose
+// per-commit stats (currently none at the per-release level — see
+// githubSource.populateReleaseContributors); Commits is always filled in.
+type Contributor struct {
+	Login     string
+	Commits   int
+	Additions int
+	Deletions int
+}
+
+// Metric returns whichever field --metric selected, for use in charts that
+// only have room for a single number per contributor.
+func (c Contributor) Metric() int {
+	switch *metricFlag {
+	case "additions":
+		return c.Additions
+	case "deletions":
+		return c.Deletions
+	default:
+		return c.Commits
+	}
+}
+
+// ContributorWeek is one row of the overall contributor activity chart: a
+// week plus each top contributor's metric value for that week.
+type ContributorWeek struct {
+	Date   time.Time
+	Values map[string]int
+}
+
+// ContributorSource is implemented by ReleaseSources that can also report
+// commit/contributor activity alongside releases.
+type ContributorSource interface {
+	// ContributorActivity fills in each release's Contributors field (commits
+	// authored within its [PublishedAt, ActiveUntil) window) and returns the
+	// weekly activity of the top-100 contributors, bounded by since/until,
+	// for the overall contributors chart.
+	ContributorActivity(ctx context.Context, spec string, rs []*release, since, until time.Time) (weeks []ContributorWeek, logins []string, err error)
+}
+
+// mergeContributorWeeks combines the per-source ContributorWeek slices produced by
+// overlaying multiple --repo flags into one row per calendar week, so the area
+// chart's data table doesn't grow a duplicate row (with the other sources' columns
+// reading back as zero) per overlaid repo.
+func mergeContributorWeeks(weeks []ContributorWeek) []ContributorWeek {
+	byDate := map[int64]*ContributorWeek{}
+	for _, w := range weeks {
+		cw, ok := byDate[w.Date.Unix()]
+		if !ok {
+			cw = &ContributorWeek{Date: w.Date, Values: map[string]int{}}
+			byDate[w.Date.Unix()] = cw
+		}
+		for login, v := range w.Values {
+			cw.Values[login] += v
+		}
+	}
+
+	merged := make([]ContributorWeek, 0, len(byDate))
+	for _, cw := range byDate {
+		merged = append(merged, *cw)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Date.Before(merged[j].Date) })
+	return merged
+}
+
+// dedupeLogins removes repeats from the concatenated per-source login lists,
+// keeping each login's first (highest-ranked) occurrence, so an overlaid
+// contributor who appears in more than one --repo doesn't get a duplicate
+// chart column.
+func dedupeLogins(logins []string) []string {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(logins))
+	for _, l := range logins {
+		if seen[l] {
+			continue
+		}
+		seen[l] = true
+		result = append(result, l)
+	}
+	return result
+}
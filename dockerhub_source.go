@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// dockerhubSource implements ReleaseSource against the Docker Hub Registry API.
+type dockerhubSource struct {
+	token string
+}
+
+// newDockerHubSource reads the token at --dockerhub-token-path, if any, and returns a ReleaseSource backed by Docker Hub.
+func newDockerHubSource(ctx context.Context) (ReleaseSource, error) {
+	token, err := readTokenFile(*dockerhubTokenPathFlag)
+	if err != nil {
+		return nil, err
+	}
+	return &dockerhubSource{token: token}, nil
+}
+
+type dockerhubRepo struct {
+	PullCount int64 `json:"pull_count"`
+}
+
+type dockerhubTag struct {
+	Name          string    `json:"name"`
+	TagLastPushed time.Time `json:"tag_last_pushed"`
+}
+
+type dockerhubTagPage struct {
+	Next    string         `json:"next"`
+	Results []dockerhubTag `json:"results"`
+}
+
+// Releases returns one release per tag for spec ("owner/image") from Docker Hub.
+//
+// Docker Hub only exposes a single pull_count per repository, not per tag, so
+// the repository's total pull count is attributed entirely to its most
+// recently pushed tag; older tags report zero downloads.
+func (d *dockerhubSource) Releases(ctx context.Context, spec string) ([]*release, error) {
+	parts := strings.SplitN(spec, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("dockerhub repo must be owner/image, got %q", spec)
+	}
+	org, image := parts[0], parts[1]
+
+	klog.Infof("Downloading Docker Hub tags for %s/%s ...", org, image)
+
+	headers := map[string]string{}
+	if d.token != "" {
+		headers["Authorization"] = "Bearer " + d.token
+	}
+
+	var repo dockerhubRepo
+	repoURL := fmt.Sprintf("https://hub.docker.com/v2/repositories/%s/%s/", org, image)
+	if err := getJSON(ctx, repoURL, headers, &repo); err != nil {
+		return nil, fmt.Errorf("dockerhub repository: %w", err)
+	}
+
+	var result []*release
+	until := time.Now()
+	first := true
+
+	tagsURL := fmt.Sprintf("https://hub.docker.com/v2/repositories/%s/%s/tags?page_size=100&ordering=-tag_last_pushed", org, image)
+	for tagsURL != "" {
+		var tags dockerhubTagPage
+		if err := getJSON(ctx, tagsURL, headers, &tags); err != nil {
+			return nil, fmt.Errorf("dockerhub tags: %w", err)
+		}
+
+		for _, t := range tags.Results {
+			rel := &release{
+				Name:           t.Name,
+				Source:         "dockerhub",
+				PublishedAt:    t.TagLastPushed,
+				ActiveUntil:    until,
+				Downloads:      map[string]int{},
+				DownloadRatios: map[string]float64{},
+			}
+
+			pulls := int64(0)
+			if first {
+				pulls = repo.PullCount
+				first = false
+			}
+			rel.Downloads["pulls"] = int(pulls)
+			rel.DownloadsTotal = pulls
+
+			until = rel.PublishedAt
+			result = append(result, rel)
+		}
+
+		tagsURL = tags.Next
+	}
+
+	return finalize(result), nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+)
+
+// echartsJS is the vendored Apache ECharts runtime (assets/echarts.min.js), inlined into
+// every echartsRenderer output so the result is viewable offline with no external CDN.
+//
+//go:embed assets/echarts.min.js
+var echartsJS string
+
+// echartsRenderer renders a self-contained HTML page using Apache ECharts instead of the
+// CDN-hosted Google Charts used by htmlRenderer.
+type echartsRenderer struct{}
+
+const echartsTmpl = `<html>
+<head>
+    <title>{{.Repo}} - Release stats</title>
+    <script type="text/javascript">{{.EchartsJS}}</script>
+</head>
+<body>
+    <h1>{{.Repo}}</h1>
+
+    <h2>Downloads Per Day</h2>
+    <div id="downloadsPerDay" style="height: 400px; width: 98%"></div>
+    <script type="text/javascript">
+        var downloadsPerDay = echarts.init(document.getElementById('downloadsPerDay'));
+        downloadsPerDay.setOption({{.DownloadsPerDayOption}});
+    </script>
+
+    <h2>Asset Mix (latest release)</h2>
+    <div id="assetMix" style="height: 400px; width: 98%"></div>
+    <script type="text/javascript">
+        var assetMix = echarts.init(document.getElementById('assetMix'));
+        assetMix.setOption({{.AssetMixOption}});
+    </script>
+</body>
+</html>
+`
+
+// echartsOption is a minimal subset of the Apache ECharts option schema, just enough for
+// the bar and pie charts below.
+type echartsOption struct {
+	Title   map[string]string        `json:"title,omitempty"`
+	Tooltip map[string]string        `json:"tooltip,omitempty"`
+	XAxis   map[string]interface{}   `json:"xAxis,omitempty"`
+	YAxis   map[string]interface{}   `json:"yAxis,omitempty"`
+	Series  []map[string]interface{} `json:"series"`
+}
+
+func (echartsRenderer) Render(repo string, rs []*release, _ []ContributorWeek, _ []string) (string, error) {
+	var names []string
+	var perDay []float64
+	for _, r := range rs {
+		if r.Prerelease {
+			continue
+		}
+		names = append(names, r.Name)
+		perDay = append(perDay, r.DownloadsPerDay)
+	}
+
+	downloadsOpt := echartsOption{
+		Tooltip: map[string]string{"trigger": "axis"},
+		XAxis:   map[string]interface{}{"type": "category", "data": names},
+		YAxis:   map[string]interface{}{"type": "value"},
+		Series:  []map[string]interface{}{{"type": "bar", "data": perDay}},
+	}
+
+	var assetNames []string
+	var assetData []map[string]interface{}
+	if latest := latestRelease(rs); latest != nil {
+		for name, count := range latest.Downloads {
+			assetNames = append(assetNames, name)
+			assetData = append(assetData, map[string]interface{}{"name": name, "value": count})
+		}
+	}
+
+	assetOpt := echartsOption{
+		Tooltip: map[string]string{"trigger": "item"},
+		Series:  []map[string]interface{}{{"type": "pie", "radius": "60%", "data": assetData}},
+	}
+
+	downloadsJSON, err := json.Marshal(downloadsOpt)
+	if err != nil {
+		return "", fmt.Errorf("marshal downloads option: %w", err)
+	}
+	assetJSON, err := json.Marshal(assetOpt)
+	if err != nil {
+		return "", fmt.Errorf("marshal asset option: %w", err)
+	}
+
+	tmpl, err := template.New("echarts").Parse(echartsTmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse tmpl: %w", err)
+	}
+
+	data := struct {
+		Repo                  string
+		EchartsJS             template.JS
+		DownloadsPerDayOption template.JS
+		AssetMixOption        template.JS
+	}{
+		Repo:                  repo,
+		EchartsJS:             template.JS(echartsJS),
+		DownloadsPerDayOption: template.JS(downloadsJSON),
+		AssetMixOption:        template.JS(assetJSON),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("execute: %w", err)
+	}
+
+	return buf.String(), nil
+}
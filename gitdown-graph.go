@@ -7,7 +7,6 @@ import (
 	"flag"
 	"fmt"
 	"html/template"
-	"io/ioutil"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -15,20 +14,52 @@ import (
 	"strings"
 	"time"
 
-	"github.com/google/go-github/v33/github"
-	"golang.org/x/oauth2"
 	"k8s.io/klog/v2"
 )
 
 const dateForm = "2006-01-02"
 
 var (
-	repoFlag      = flag.String("repo", "", "GitHub repo to inquire about")
+	repoFlag      repoList
 	tokenPathFlag = flag.String("token-path", "", "GitHub token path")
 
+	gitlabTokenPathFlag    = flag.String("gitlab-token-path", "", "GitLab token path")
+	giteaTokenPathFlag     = flag.String("gitea-token-path", "", "Gitea token path")
+	dockerhubTokenPathFlag = flag.String("dockerhub-token-path", "", "Docker Hub token path")
+
+	metricFlag = flag.String("metric", "commits", "contributor activity metric to chart: commits, additions, or deletions")
+	sinceFlag  = flag.String("since", "", "only include contributor activity on or after this date ("+dateForm+")")
+	untilFlag  = flag.String("until", "", "only include contributor activity on or before this date ("+dateForm+")")
+
+	historyPathFlag      = flag.String("history-path", "", "path to a JSON file recording per-asset download snapshots across runs, enabling DownloadsDelta and a real downloads/day time series")
+	historyRetentionFlag = flag.Duration("history-retention", 90*24*time.Hour, "how long to keep snapshots in --history-path before pruning old ones; 0 disables pruning")
+	intervalFlag         = flag.Duration("interval", time.Hour, "how often --serve re-scrapes and records a new snapshot")
+	serveFlag            = flag.String("serve", "", "if set, run as a daemon listening on this address (e.g. :8080) instead of printing to stdout, re-scraping every --interval")
+
+	classifierConfigFlag = flag.String("classifier-config", "", "path to a YAML file overriding the built-in OS/Arch/PackageFormat asset classifier")
+
+	formatFlag = flag.String("format", "html", "output renderer: html, json, csv, prom, or echarts")
+	listenFlag = flag.String("listen", "", "if set (with --format=prom), expose Prometheus metrics at /metrics on this address, refreshing every --interval")
+
 	ignoreAssetRe = regexp.MustCompile(`\.sha256|VERSION`)
 )
 
+func init() {
+	flag.Var(&repoFlag, "repo", "repo to inquire about, may be repeated to overlay multiple projects (prefix with gitlab:, gitea:, or dockerhub: for non-GitHub sources)")
+}
+
+// repoList is a flag.Value that accumulates repeated --repo flags.
+type repoList []string
+
+func (r *repoList) String() string {
+	return strings.Join(*r, ",")
+}
+
+func (r *repoList) Set(v string) error {
+	*r = append(*r, v)
+	return nil
+}
+
 const htmlTmpl = `<html>
 <head>
     <title>{{ .Repo }} - Release stats</title>
@@ -126,7 +157,7 @@ const htmlTmpl = `<html>
 		function drawReleaseFreq() {
 			var data = new google.visualization.arrayToDataTable([
 			['Release', 'Days active', { role: 'annotation' }],
-			{{ range .Releases }}{{ if not .Prerelease }}["{{.Name}}", {{.DaysActive}}, "{{printf "%0.f" .DaysActive}}"],{{ end }}
+			{{ range .Releases }}{{ if not .Prerelease }}["{{ SourceIcon .Source }} {{.Name}}", {{.DaysActive}}, "{{printf "%0.f" .DaysActive}}"],{{ end }}
 			{{ end }}
 			]);
 
@@ -156,8 +187,8 @@ const htmlTmpl = `<html>
 	function assetMix() {
 		var data = new google.visualization.arrayToDataTable([
 			['Asset', 'Downloads', { role: 'annotation' }],
-			{{ range $key, $value := .Latest.Downloads }}["{{$key}}", {{$value}}, "{{$key}} ({{$value}})"],
-			{{ end }}
+			{{ if .Latest }}{{ range $key, $value := .Latest.Downloads }}["{{$key}}", {{$value}}, "{{$key}} ({{$value}})"],
+			{{ end }}{{ end }}
 			]);
 	  var chart = new google.visualization.PieChart(document.getElementById('assetMix'));
 	  var options = {};
@@ -165,6 +196,54 @@ const htmlTmpl = `<html>
 	}
   </script>
 
+	<h2>Downloads by OS</h2>
+	<div id="osMix" style="height: 400px"></div>
+	<script type="text/javascript">
+	google.charts.setOnLoadCallback(osMix);
+	function osMix() {
+		var data = new google.visualization.arrayToDataTable([
+			['OS', 'Downloads', { role: 'annotation' }],
+			{{ if .Latest }}{{ range $key, $value := .Latest.DownloadsByOS }}["{{$key}}", {{$value}}, "{{$key}} ({{$value}})"],
+			{{ end }}{{ end }}
+			]);
+	  var chart = new google.visualization.PieChart(document.getElementById('osMix'));
+	  var options = {};
+	  chart.draw(data, options);
+	}
+  </script>
+
+	<h2>Downloads by Architecture</h2>
+	<div id="archMix" style="height: 400px"></div>
+	<script type="text/javascript">
+	google.charts.setOnLoadCallback(archMix);
+	function archMix() {
+		var data = new google.visualization.arrayToDataTable([
+			['Arch', 'Downloads', { role: 'annotation' }],
+			{{ if .Latest }}{{ range $key, $value := .Latest.DownloadsByArch }}["{{$key}}", {{$value}}, "{{$key}} ({{$value}})"],
+			{{ end }}{{ end }}
+			]);
+	  var chart = new google.visualization.PieChart(document.getElementById('archMix'));
+	  var options = {};
+	  chart.draw(data, options);
+	}
+  </script>
+
+	<h2>Downloads by Package Format</h2>
+	<div id="formatMix" style="height: 400px"></div>
+	<script type="text/javascript">
+	google.charts.setOnLoadCallback(formatMix);
+	function formatMix() {
+		var data = new google.visualization.arrayToDataTable([
+			['Format', 'Downloads', { role: 'annotation' }],
+			{{ if .Latest }}{{ range $key, $value := .Latest.DownloadsByFormat }}["{{$key}}", {{$value}}, "{{$key}} ({{$value}})"],
+			{{ end }}{{ end }}
+			]);
+	  var chart = new google.visualization.PieChart(document.getElementById('formatMix'));
+	  var options = {};
+	  chart.draw(data, options);
+	}
+  </script>
+
 
 	<h2>Downloads Per Day</h2>
 
@@ -175,7 +254,7 @@ const htmlTmpl = `<html>
 		function drawDownloadAvg() {
 			var data = new google.visualization.arrayToDataTable([
 			['Release', 'Downloads per day', { role: 'annotation' }],
-			{{ range .Releases }}{{ if not .Prerelease }}["{{.Name}}", {{.DownloadsPerDay}}, "{{.DownloadsTotal}}"],{{ end }}
+			{{ range .Releases }}{{ if not .Prerelease }}["{{ SourceIcon .Source }} {{.Name}}", {{.DownloadsPerDay}}, "{{.DownloadsTotal}}"],{{ end }}
 			{{ end }}
 			]);
 
@@ -218,6 +297,94 @@ const htmlTmpl = `<html>
 	</script>
 	</div>
 
+	<h2>Downloads Per Day (recorded snapshots)</h2>
+
+	<div id="downloadHistory" style="height: 400px; width: 98%"></div>
+	<script type="text/javascript">
+		google.charts.setOnLoadCallback(drawDownloadHistory);
+
+		function drawDownloadHistory() {
+			var data = new google.visualization.arrayToDataTable([
+			['Snapshot', {{ range .HistoryNames }}'{{.}}', {{ end }}],
+			{{ range $row := .HistoryRows }}["{{ $row.Date | Date }}", {{ range $.HistoryNames }}{{ HistVal $row . }}, {{ end }}],
+			{{ end }}
+			]);
+
+			var options = {
+			};
+
+		   var chart = new google.visualization.LineChart(document.getElementById('downloadHistory'));
+		   chart.draw(data, options);
+		};
+	</script>
+	</div>
+
+	<h2>Contributors ({{.Metric}})</h2>
+
+	<div id="contributorActivity" style="height: 400px; width: 98%"></div>
+	<script type="text/javascript">
+		google.charts.setOnLoadCallback(drawContributorActivity);
+
+		function drawContributorActivity() {
+			var data = new google.visualization.arrayToDataTable([
+			['Week', {{ range .ContributorLogins }}'{{.}}', {{ end }}],
+			{{ range $week := .ContributorWeeks }}["{{ $week.Date | Date }}", {{ range $.ContributorLogins }}{{ Val $week . }}, {{ end }}],
+			{{ end }}
+			]);
+
+			var options = {
+			isStacked: true,
+			};
+
+		   var chart = new google.visualization.AreaChart(document.getElementById('contributorActivity'));
+		   chart.draw(data, options);
+		};
+	</script>
+	</div>
+
+	<h2>Top Contributors (latest release, {{.Metric}})</h2>
+	<div id="topContributors" style="height: 400px"></div>
+	<script type="text/javascript">
+	google.charts.setOnLoadCallback(topContributors);
+	function topContributors() {
+		var data = new google.visualization.arrayToDataTable([
+			['Contributor', '{{.Metric}}', { role: 'annotation' }],
+			{{ if .Latest }}{{ range .Latest.Contributors }}["{{.Login}}", {{.Metric}}, "{{.Login}} ({{.Metric}})"],
+			{{ end }}{{ end }}
+			]);
+	  var chart = new google.visualization.PieChart(document.getElementById('topContributors'));
+	  var options = {};
+	  chart.draw(data, options);
+	}
+  </script>
+
+	<h2>Per-Contributor Activity (top {{ len .ContributorLogins }}, {{.Metric}})</h2>
+	<div style="display: flex; flex-wrap: wrap;">
+	{{ range $i, $login := .ContributorLogins }}
+		<div>
+			<div style="font-size: 12px; text-align: center">{{ $login }}</div>
+			<div id="contributorMini{{ $i }}" style="height: 120px; width: 220px"></div>
+		</div>
+	{{ end }}
+	</div>
+	<script type="text/javascript">
+	google.charts.setOnLoadCallback(drawContributorMiniCharts);
+	function drawContributorMiniCharts() {
+	{{ range $i, $login := .ContributorLogins }}
+		(function() {
+			var data = new google.visualization.arrayToDataTable([
+			['Week', '{{ $login }}'],
+			{{ range $week := $.ContributorWeeks }}["{{ $week.Date | Date }}", {{ Val $week $login }}],
+			{{ end }}
+			]);
+			var options = { legend: 'none', width: 220, height: 120, chartArea: { width: '90%', height: '70%' } };
+			var chart = new google.visualization.LineChart(document.getElementById('contributorMini{{ $i }}'));
+			chart.draw(data, options);
+		})();
+	{{ end }}
+	}
+  </script>
+
 </body>
 </html>
 `
@@ -229,32 +396,121 @@ func main() {
 
 	flag.Parse()
 
-	if *repoFlag == "" || *tokenPathFlag == "" {
-		fmt.Println("usage: gitdown --repo <repository> --token-path <github token path>")
+	if len(repoFlag) == 0 {
+		fmt.Println("usage: gitdown --repo <repository> [--repo <repository> ...] --token-path <github token path>")
 		os.Exit(2)
 	}
 
 	ctx := context.Background()
-	token, err := ioutil.ReadFile(*tokenPathFlag)
+
+	if *listenFlag != "" || *serveFlag != "" {
+		if *intervalFlag <= 0 {
+			fmt.Println("usage: --interval must be positive when using --listen or --serve")
+			os.Exit(2)
+		}
+	}
+
+	if *listenFlag != "" {
+		if *formatFlag != "prom" {
+			fmt.Println("usage: --listen requires --format=prom")
+			os.Exit(2)
+		}
+		serveMetrics(ctx, *listenFlag, *intervalFlag)
+		return
+	}
+
+	if *serveFlag != "" {
+		serve(ctx, *serveFlag, *intervalFlag)
+		return
+	}
+
+	out, err := gatherAndRender(ctx)
 	if err != nil {
-		klog.Exitf("token file: %v", err)
+		klog.Exitf("%v", err)
 	}
 
-	tc := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: strings.TrimSpace(string(token))}))
-	c := github.NewClient(tc)
+	fmt.Print(out)
+}
 
-	org, repo := parseRepo(*repoFlag)
-	rs, err := releases(ctx, c, org, repo)
+// gatherAndRender scrapes every --repo, applies the --history-path snapshot store, and
+// renders the resulting HTML. It is the unit of work repeated on each --interval tick in
+// --serve mode.
+func gatherAndRender(ctx context.Context) (string, error) {
+	since, err := parseOptionalDate(*sinceFlag)
 	if err != nil {
-		klog.Exitf("gather failed: %v", err)
+		return "", fmt.Errorf("--since: %w", err)
 	}
+	until, err := parseOptionalDate(*untilFlag)
+	if err != nil {
+		return "", fmt.Errorf("--until: %w", err)
+	}
+
+	var rs []*release
+	var weeks []ContributorWeek
+	var logins []string
+	for _, spec := range repoFlag {
+		prefix, rawRepo := splitSourcePrefix(spec)
+
+		src, err := newReleaseSource(ctx, prefix)
+		if err != nil {
+			return "", fmt.Errorf("source %s: %w", prefix, err)
+		}
 
-	out, err := render(*repoFlag, rs)
+		rels, err := src.Releases(ctx, rawRepo)
+		if err != nil {
+			return "", fmt.Errorf("gather failed for %s: %w", spec, err)
+		}
+
+		if cs, ok := src.(ContributorSource); ok {
+			w, l, err := cs.ContributorActivity(ctx, rawRepo, rels, since, until)
+			if err != nil {
+				return "", fmt.Errorf("contributor activity failed for %s: %w", spec, err)
+			}
+			weeks = append(weeks, w...)
+			logins = append(logins, l...)
+		}
+
+		for _, r := range rels {
+			r.RepoSpec = spec
+		}
+
+		rs = append(rs, rels...)
+	}
+
+	weeks = mergeContributorWeeks(weeks)
+	logins = dedupeLogins(logins)
+
+	if *historyPathFlag != "" {
+		if err := applyHistory(*historyPathFlag, time.Now(), *historyRetentionFlag, rs); err != nil {
+			return "", fmt.Errorf("history: %w", err)
+		}
+	}
+
+	classifierCfg, err := loadClassifierConfig(*classifierConfigFlag)
 	if err != nil {
-		klog.Exitf("render failed: %v", err)
+		return "", fmt.Errorf("--classifier-config: %w", err)
 	}
+	classifyAndAggregate(classifierCfg, rs)
 
-	fmt.Print(out)
+	r, ok := renderers[*formatFlag]
+	if !ok {
+		return "", fmt.Errorf("unknown --format %q", *formatFlag)
+	}
+
+	out, err := r.Render(strings.Join(repoFlag, ", "), rs, weeks, logins)
+	if err != nil {
+		return "", fmt.Errorf("render failed: %w", err)
+	}
+
+	return out, nil
+}
+
+// parseOptionalDate parses s as dateForm, returning the zero time if s is empty.
+func parseOptionalDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(dateForm, s)
 }
 
 // parseRepo returns the organization and project for a URL or partial path
@@ -274,68 +530,30 @@ func parseRepo(rawURL string) (string, string) {
 
 // release represents a processed release
 type release struct {
-	Name            string
-	Draft           bool
-	Prerelease      bool
-	PublishedAt     time.Time
-	ActiveUntil     time.Time
-	DaysActive      float64
-	DownloadsTotal  int64
-	DownloadsPerDay float64
-	Downloads       map[string]int
-	DownloadRatios  map[string]float64
+	Name                  string
+	Source                string // registry key of the ReleaseSource that produced this release (e.g. "github", "gitlab")
+	RepoSpec              string // the --repo value this release came from, used to key history snapshots
+	Draft                 bool
+	Prerelease            bool
+	PublishedAt           time.Time
+	ActiveUntil           time.Time
+	DaysActive            float64
+	DownloadsTotal        int64
+	DownloadsPerDay       float64
+	Downloads             map[string]int
+	DownloadRatios        map[string]float64
+	Contributors          []Contributor
+	DownloadsDelta        map[string]int // per-asset change since the previous --history-path snapshot
+	DaysSinceLastSnapshot float64
+	History               []HistoryPoint // downloads/day at each recorded snapshot, oldest first
+	DownloadsByOS         map[string]int // overlay-wide downloads across all non-prerelease releases, by classified OS; only set on the latest release
+	DownloadsByArch       map[string]int // overlay-wide downloads across all non-prerelease releases, by classified architecture; only set on the latest release
+	DownloadsByFormat     map[string]int // overlay-wide downloads across all non-prerelease releases, by classified package format; only set on the latest release
 }
 
-// releases returns a list of pull requests in a project
-func releases(ctx context.Context, c *github.Client, org string, project string) ([]*release, error) {
-	var result []*release
-
-	opts := &github.ListOptions{PerPage: 100}
-
-	klog.Infof("Downloading releases for %s/%s ...", org, project)
-
-	for page := 1; page != 0; {
-		opts.Page = page
-		rs, resp, err := c.Repositories.ListReleases(ctx, org, project, opts)
-		if err != nil {
-			return result, err
-		}
-
-		page = resp.NextPage
-		until := time.Now()
-
-		for _, r := range rs {
-			name := r.GetName()
-			if name == "" {
-				name = r.GetTagName()
-			}
-
-			rel := &release{
-				Name:           name,
-				Draft:          r.GetDraft(),
-				Prerelease:     r.GetPrerelease(),
-				PublishedAt:    r.GetPublishedAt().Time,
-				ActiveUntil:    until,
-				Downloads:      map[string]int{},
-				DownloadRatios: map[string]float64{},
-			}
-
-			for _, a := range r.Assets {
-				if ignoreAssetRe.MatchString(a.GetName()) {
-					continue
-				}
-				rel.Downloads[a.GetName()] = a.GetDownloadCount()
-				rel.DownloadsTotal += int64(a.GetDownloadCount())
-			}
-
-			if !rel.Draft && !rel.Prerelease {
-				until = rel.PublishedAt
-			}
-
-			result = append(result, rel)
-		}
-	}
-
+// finalize computes the derived fields (DaysActive, DownloadsPerDay, DownloadRatios)
+// shared by every ReleaseSource once raw release data has been gathered.
+func finalize(result []*release) []*release {
 	for _, r := range result {
 		r.DaysActive = r.ActiveUntil.Sub(r.PublishedAt).Hours() / 24
 		r.DownloadsPerDay = float64(r.DownloadsTotal) / r.DaysActive
@@ -345,33 +563,69 @@ func releases(ctx context.Context, c *github.Client, org string, project string)
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 func dateStr(t time.Time) string {
 	return t.Format(dateForm)
 }
 
-func render(repo string, rs []*release) (string, error) {
-	funcMap := template.FuncMap{"Date": dateStr}
+// latestRelease returns the most recently published non-draft, non-prerelease release in
+// rs, or nil if there is none (e.g. rs is empty, or every release was filtered out). With
+// --repo overlay, rs is the concatenation of each source's releases in flag order, so the
+// overall latest must be found by PublishedAt rather than assumed to be rs[0].
+func latestRelease(rs []*release) *release {
+	var latest *release
+	for _, r := range rs {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+		if latest == nil || r.PublishedAt.After(latest.PublishedAt) {
+			latest = r
+		}
+	}
+	return latest
+}
+
+// renderHTML renders the default Google Charts dashboard, the original (and richest)
+// output format. Other formats are implemented by the remaining Renderer in renderers.go.
+func renderHTML(repo string, rs []*release, weeks []ContributorWeek, logins []string) (string, error) {
+	funcMap := template.FuncMap{
+		"Date":       dateStr,
+		"SourceIcon": sourceIcon,
+		"Val":        func(w ContributorWeek, login string) int { return w.Values[login] },
+		"HistVal":    func(row historyRow, name string) float64 { return row.Values[name] },
+	}
 	tmpl, err := template.New("Releases").Funcs(funcMap).Parse(htmlTmpl)
 	if err != nil {
 		return "", fmt.Errorf("parse tmpl: %v", err)
 	}
 
+	historyRows, historyNames := buildHistoryRows(rs)
+
 	data := struct {
-		Title     string
-		Repo      string
-		Command   string
-		BarHeight int
-		Releases  []*release
-		Latest    *release
+		Title             string
+		Repo              string
+		Command           string
+		BarHeight         int
+		Releases          []*release
+		Latest            *release
+		Metric            string
+		ContributorWeeks  []ContributorWeek
+		ContributorLogins []string
+		HistoryRows       []historyRow
+		HistoryNames      []string
 	}{
-		Repo:      repo,
-		Command:   filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " "),
-		BarHeight: 64 + (20 * len(rs)),
-		Releases:  rs,
-		Latest:    rs[0],
+		Repo:              repo,
+		Command:           filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " "),
+		BarHeight:         64 + (20 * len(rs)),
+		Releases:          rs,
+		Latest:            latestRelease(rs),
+		Metric:            *metricFlag,
+		ContributorWeeks:  weeks,
+		ContributorLogins: logins,
+		HistoryRows:       historyRows,
+		HistoryNames:      historyNames,
 	}
 
 	var tpl bytes.Buffer
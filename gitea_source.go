@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// giteaPerPage is the page size requested from Gitea's releases endpoint;
+// the last page of any listing has fewer results, which is how we detect the end.
+const giteaPerPage = 50
+
+// giteaSource implements ReleaseSource against a self-hosted Gitea instance's REST API.
+type giteaSource struct {
+	token string
+}
+
+// newGiteaSource reads the token at --gitea-token-path, if any, and returns a ReleaseSource backed by Gitea.
+func newGiteaSource(ctx context.Context) (ReleaseSource, error) {
+	token, err := readTokenFile(*giteaTokenPathFlag)
+	if err != nil {
+		return nil, err
+	}
+	return &giteaSource{token: token}, nil
+}
+
+// giteaRelease mirrors the fields of Gitea's /repos/{owner}/{repo}/releases that we use.
+type giteaRelease struct {
+	Name        string    `json:"name"`
+	TagName     string    `json:"tag_name"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []struct {
+		Name          string `json:"name"`
+		DownloadCount int    `json:"download_count"`
+	} `json:"assets"`
+}
+
+// Releases returns the releases for spec ("host/owner/repo") from a Gitea instance.
+func (g *giteaSource) Releases(ctx context.Context, spec string) ([]*release, error) {
+	parts := strings.SplitN(spec, "/", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("gitea repo must be host/owner/repo, got %q", spec)
+	}
+	host, org, project := parts[0], parts[1], parts[2]
+
+	klog.Infof("Downloading Gitea releases for %s/%s/%s ...", host, org, project)
+
+	headers := map[string]string{}
+	if g.token != "" {
+		headers["Authorization"] = "token " + g.token
+	}
+
+	var result []*release
+	until := time.Now()
+
+	for page := 1; ; page++ {
+		apiURL := fmt.Sprintf("https://%s/api/v1/repos/%s/%s/releases?limit=%d&page=%d", host, org, project, giteaPerPage, page)
+
+		var rs []giteaRelease
+		if err := getJSON(ctx, apiURL, headers, &rs); err != nil {
+			return nil, fmt.Errorf("gitea releases: %w", err)
+		}
+
+		for _, r := range rs {
+			name := r.Name
+			if name == "" {
+				name = r.TagName
+			}
+
+			rel := &release{
+				Name:           name,
+				Source:         "gitea",
+				Draft:          r.Draft,
+				Prerelease:     r.Prerelease,
+				PublishedAt:    r.PublishedAt,
+				ActiveUntil:    until,
+				Downloads:      map[string]int{},
+				DownloadRatios: map[string]float64{},
+			}
+
+			for _, a := range r.Assets {
+				if ignoreAssetRe.MatchString(a.Name) {
+					continue
+				}
+				rel.Downloads[a.Name] = a.DownloadCount
+				rel.DownloadsTotal += int64(a.DownloadCount)
+			}
+
+			if !rel.Draft && !rel.Prerelease {
+				until = rel.PublishedAt
+			}
+
+			result = append(result, rel)
+		}
+
+		if len(rs) < giteaPerPage {
+			break
+		}
+	}
+
+	return finalize(result), nil
+}
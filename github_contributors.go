@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"k8s.io/klog/v2"
+)
+
+// maxContributors bounds the per-release and overall contributor charts to the top-100
+// contributors, matching Gitea's contributors view.
+const maxContributors = 100
+
+// maxStatsAttempts bounds how many times overallActivity polls GitHub's contributor-stats
+// cache before giving up, so a repo whose cache never finishes computing fails instead of
+// hanging the run forever.
+const maxStatsAttempts = 10
+
+// ContributorActivity fills each release's Contributors field and returns the
+// weekly activity of the top-100 contributors for the overall chart.
+func (g *githubSource) ContributorActivity(ctx context.Context, spec string, rs []*release, since, until time.Time) ([]ContributorWeek, []string, error) {
+	org, project := parseRepo(spec)
+
+	if err := g.populateReleaseContributors(ctx, org, project, rs); err != nil {
+		return nil, nil, err
+	}
+
+	return g.overallActivity(ctx, org, project, since, until)
+}
+
+// populateReleaseContributors fills each release's Contributors with a commit count per
+// author, counting commits authored within the release's [PublishedAt, ActiveUntil) window.
+func (g *githubSource) populateReleaseContributors(ctx context.Context, org, project string, rs []*release) error {
+	klog.Infof("Downloading commits for %s/%s ...", org, project)
+
+	for _, r := range rs {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+
+		counts := map[string]*Contributor{}
+		opts := &github.CommitsListOptions{
+			Since:       r.PublishedAt,
+			Until:       r.ActiveUntil,
+			ListOptions: github.ListOptions{PerPage: 100},
+		}
+
+		for page := 1; page != 0; {
+			opts.Page = page
+			commits, resp, err := g.c.Repositories.ListCommits(ctx, org, project, opts)
+			if err != nil {
+				return err
+			}
+			page = resp.NextPage
+
+			for _, cm := range commits {
+				login := cm.GetAuthor().GetLogin()
+				if login == "" {
+					login = cm.GetCommit().GetAuthor().GetName()
+				}
+
+				cb, ok := counts[login]
+				if !ok {
+					cb = &Contributor{Login: login}
+					counts[login] = cb
+				}
+				cb.Commits++
+			}
+		}
+
+		for _, cb := range counts {
+			r.Contributors = append(r.Contributors, *cb)
+		}
+		sort.Slice(r.Contributors, func(i, j int) bool { return r.Contributors[i].Commits > r.Contributors[j].Commits })
+		if len(r.Contributors) > maxContributors {
+			r.Contributors = r.Contributors[:maxContributors]
+		}
+	}
+
+	return nil
+}
+
+// overallActivity returns the weekly commit/addition/deletion activity of the
+// top-100 contributors, bounded by since/until, via the repository stats API.
+func (g *githubSource) overallActivity(ctx context.Context, org, project string, since, until time.Time) ([]ContributorWeek, []string, error) {
+	var stats []*github.ContributorStats
+	for attempt := 0; ; attempt++ {
+		s, resp, err := g.c.Repositories.ListContributorsStats(ctx, org, project)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.StatusCode == http.StatusAccepted {
+			if attempt >= maxStatsAttempts {
+				return nil, nil, fmt.Errorf("contributor stats for %s/%s did not finish computing after %d attempts", org, project, attempt+1)
+			}
+			// GitHub is still computing the stats; back off and retry.
+			klog.Infof("contributor stats for %s/%s still computing, retrying ...", org, project)
+			select {
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			case <-time.After(2 * time.Second):
+			}
+			continue
+		}
+		stats = s
+		break
+	}
+
+	// Rank by activity within [since, until], not lifetime total, so a contributor who
+	// only became active in the selected range isn't bumped by someone with a bigger
+	// lifetime total but no activity in range.
+	inWindow := func(t time.Time) bool {
+		if !since.IsZero() && t.Before(since) {
+			return false
+		}
+		if !until.IsZero() && t.After(until) {
+			return false
+		}
+		return true
+	}
+
+	windowTotal := func(s *github.ContributorStats) int {
+		total := 0
+		for _, w := range s.Weeks {
+			if !inWindow(w.GetWeek().Time) {
+				continue
+			}
+			c := Contributor{Commits: w.GetCommits(), Additions: w.GetAdditions(), Deletions: w.GetDeletions()}
+			total += c.Metric()
+		}
+		return total
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return windowTotal(stats[i]) > windowTotal(stats[j]) })
+	if len(stats) > maxContributors {
+		stats = stats[:maxContributors]
+	}
+
+	logins := make([]string, 0, len(stats))
+	weeks := map[int64]*ContributorWeek{}
+
+	for _, s := range stats {
+		login := s.GetAuthor().GetLogin()
+		logins = append(logins, login)
+
+		for _, w := range s.Weeks {
+			t := w.GetWeek().Time
+			if !inWindow(t) {
+				continue
+			}
+
+			cw, ok := weeks[t.Unix()]
+			if !ok {
+				cw = &ContributorWeek{Date: t, Values: map[string]int{}}
+				weeks[t.Unix()] = cw
+			}
+
+			c := Contributor{Login: login, Commits: w.GetCommits(), Additions: w.GetAdditions(), Deletions: w.GetDeletions()}
+			cw.Values[login] = c.Metric()
+		}
+	}
+
+	result := make([]ContributorWeek, 0, len(weeks))
+	for _, cw := range weeks {
+		result = append(result, *cw)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+
+	return result, logins, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"golang.org/x/oauth2"
+	"k8s.io/klog/v2"
+)
+
+// githubSource implements ReleaseSource against the GitHub API.
+type githubSource struct {
+	c *github.Client
+}
+
+// newGitHubSource reads the token at --token-path and returns a ReleaseSource backed by GitHub.
+func newGitHubSource(ctx context.Context) (ReleaseSource, error) {
+	token, err := ioutil.ReadFile(*tokenPathFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	tc := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: strings.TrimSpace(string(token))}))
+	return &githubSource{c: github.NewClient(tc)}, nil
+}
+
+// Releases returns the releases for spec ("owner/repo" or a GitHub URL) from GitHub.
+func (g *githubSource) Releases(ctx context.Context, spec string) ([]*release, error) {
+	org, project := parseRepo(spec)
+	var result []*release
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	klog.Infof("Downloading releases for %s/%s ...", org, project)
+
+	for page := 1; page != 0; {
+		opts.Page = page
+		rs, resp, err := g.c.Repositories.ListReleases(ctx, org, project, opts)
+		if err != nil {
+			return result, err
+		}
+
+		page = resp.NextPage
+		until := time.Now()
+
+		for _, r := range rs {
+			name := r.GetName()
+			if name == "" {
+				name = r.GetTagName()
+			}
+
+			rel := &release{
+				Name:           name,
+				Source:         "github",
+				Draft:          r.GetDraft(),
+				Prerelease:     r.GetPrerelease(),
+				PublishedAt:    r.GetPublishedAt().Time,
+				ActiveUntil:    until,
+				Downloads:      map[string]int{},
+				DownloadRatios: map[string]float64{},
+			}
+
+			for _, a := range r.Assets {
+				if ignoreAssetRe.MatchString(a.GetName()) {
+					continue
+				}
+				rel.Downloads[a.GetName()] = a.GetDownloadCount()
+				rel.DownloadsTotal += int64(a.GetDownloadCount())
+			}
+
+			if !rel.Draft && !rel.Prerelease {
+				until = rel.PublishedAt
+			}
+
+			result = append(result, rel)
+		}
+	}
+
+	return finalize(result), nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// gitlabPerPage is the page size requested from GitLab's releases endpoint;
+// the last page of any listing has fewer results, which is how we detect the end.
+const gitlabPerPage = 100
+
+// gitlabSource implements ReleaseSource against the GitLab REST API.
+type gitlabSource struct {
+	token string
+}
+
+// newGitLabSource reads the token at --gitlab-token-path, if any, and returns a ReleaseSource backed by GitLab.
+func newGitLabSource(ctx context.Context) (ReleaseSource, error) {
+	token, err := readTokenFile(*gitlabTokenPathFlag)
+	if err != nil {
+		return nil, err
+	}
+	return &gitlabSource{token: token}, nil
+}
+
+// gitlabRelease mirrors the fields of https://docs.gitlab.com/ee/api/releases/ that we use.
+type gitlabRelease struct {
+	Name        string    `json:"name"`
+	TagName     string    `json:"tag_name"`
+	ReleasedAt  time.Time `json:"released_at"`
+	UpcomingRel bool      `json:"upcoming_release"`
+	Assets      struct {
+		Links []struct {
+			Name string `json:"name"`
+		} `json:"links"`
+	} `json:"assets"`
+}
+
+// Releases returns the releases for spec ("owner/repo") from GitLab.
+func (g *gitlabSource) Releases(ctx context.Context, spec string) ([]*release, error) {
+	parts := strings.SplitN(spec, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("gitlab repo must be owner/repo, got %q", spec)
+	}
+	org, project := parts[0], parts[1]
+
+	klog.Infof("Downloading GitLab releases for %s/%s ...", org, project)
+
+	headers := map[string]string{}
+	if g.token != "" {
+		headers["PRIVATE-TOKEN"] = g.token
+	}
+
+	projectID := url.QueryEscape(org + "/" + project)
+
+	var result []*release
+	until := time.Now()
+
+	for page := 1; ; page++ {
+		apiURL := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/releases?per_page=%d&page=%d", projectID, gitlabPerPage, page)
+
+		var rs []gitlabRelease
+		if err := getJSON(ctx, apiURL, headers, &rs); err != nil {
+			return nil, fmt.Errorf("gitlab releases: %w", err)
+		}
+
+		for _, r := range rs {
+			name := r.Name
+			if name == "" {
+				name = r.TagName
+			}
+
+			rel := &release{
+				Name:           name,
+				Source:         "gitlab",
+				Prerelease:     r.UpcomingRel,
+				PublishedAt:    r.ReleasedAt,
+				ActiveUntil:    until,
+				Downloads:      map[string]int{},
+				DownloadRatios: map[string]float64{},
+			}
+
+			// GitLab releases don't expose per-asset download counts, only link names.
+			for _, l := range r.Assets.Links {
+				rel.Downloads[l.Name] = 0
+			}
+
+			if !rel.Prerelease {
+				until = rel.PublishedAt
+			}
+
+			result = append(result, rel)
+		}
+
+		if len(rs) < gitlabPerPage {
+			break
+		}
+	}
+
+	return finalize(result), nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+)
+
+// historyRow is one row of the cross-release downloads/day time series chart: a recorded
+// snapshot timestamp plus each non-prerelease release's downloads/day as of that snapshot.
+type historyRow struct {
+	Date   time.Time
+	Values map[string]float64
+}
+
+// historyColumn returns the chart column key/label for r: its repo spec and release name,
+// so two repos that happen to share a tag name don't collapse into one column.
+func historyColumn(r *release) string {
+	return r.RepoSpec + " " + r.Name
+}
+
+// buildHistoryRows pivots each release's History into rows keyed by snapshot timestamp,
+// for charting every release's downloads/day on a shared time axis. It returns the rows,
+// oldest first, and the repo/release column names, keyed by historyColumn.
+func buildHistoryRows(rs []*release) ([]historyRow, []string) {
+	var names []string
+	rows := map[int64]*historyRow{}
+
+	for _, r := range rs {
+		if r.Prerelease {
+			continue
+		}
+		col := historyColumn(r)
+		names = append(names, col)
+
+		for _, p := range r.History {
+			row, ok := rows[p.Date.Unix()]
+			if !ok {
+				row = &historyRow{Date: p.Date, Values: map[string]float64{}}
+				rows[p.Date.Unix()] = row
+			}
+			row.Values[col] = p.DownloadsPerDay
+		}
+	}
+
+	out := make([]historyRow, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *row)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+
+	return out, names
+}
+
+// HistoryPoint is one recorded data point in a release's downloads/day time series.
+type HistoryPoint struct {
+	Date            time.Time
+	DownloadsPerDay float64
+}
+
+// historySnapshot is one run's recorded per-asset download counts for a single release.
+type historySnapshot struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Repo      string         `json:"repo"`
+	Release   string         `json:"release"`
+	Downloads map[string]int `json:"downloads"`
+}
+
+// historyStore is the on-disk contents of --history-path: every snapshot ever recorded.
+type historyStore struct {
+	Snapshots []historySnapshot `json:"snapshots"`
+}
+
+// loadHistory reads path, returning an empty store if it doesn't exist yet.
+func loadHistory(path string) (*historyStore, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &historyStore{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var h historyStore
+	if err := json.Unmarshal(b, &h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// save writes h to path atomically, via a temp file and rename.
+func (h *historyStore) save(path string) error {
+	b, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// forRelease returns every snapshot recorded for repo/release, oldest first.
+func (h *historyStore) forRelease(repo, release string) []historySnapshot {
+	var out []historySnapshot
+	for _, s := range h.Snapshots {
+		if s.Repo == repo && s.Release == release {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Timestamp.Before(out[j].Timestamp) })
+	return out
+}
+
+// applyHistory reads the snapshot store at path, fills in each release's DownloadsDelta,
+// DaysSinceLastSnapshot, and History time series from snapshots recorded so far, then
+// appends a new snapshot of the current counts and saves the store atomically.
+//
+// Snapshots older than retention are dropped first, so a long-running --serve daemon
+// doesn't grow the store (and the cost of reading/re-marshaling it every tick) without
+// bound; retention <= 0 disables pruning.
+func applyHistory(path string, now time.Time, retention time.Duration, rs []*release) error {
+	h, err := loadHistory(path)
+	if err != nil {
+		return err
+	}
+
+	if retention > 0 {
+		h.Snapshots = pruneSnapshots(h.Snapshots, now.Add(-retention))
+	}
+
+	var newSnapshots []historySnapshot
+	for _, r := range rs {
+		prior := h.forRelease(r.RepoSpec, r.Name)
+
+		r.DownloadsDelta = map[string]int{}
+		if len(prior) > 0 {
+			last := prior[len(prior)-1]
+			r.DaysSinceLastSnapshot = now.Sub(last.Timestamp).Hours() / 24
+			for asset, count := range r.Downloads {
+				r.DownloadsDelta[asset] = count - last.Downloads[asset]
+			}
+		}
+
+		// Chart the rate between each consecutive pair of snapshots, not the cumulative
+		// average since publish, so the series actually shows trends rather than an
+		// ever-flattening curve.
+		points := append(prior, historySnapshot{Timestamp: now, Repo: r.RepoSpec, Release: r.Name, Downloads: r.Downloads})
+		for i := 1; i < len(points); i++ {
+			if pt, ok := downloadsPerDayBetween(points[i-1], points[i]); ok {
+				r.History = append(r.History, pt)
+			}
+		}
+
+		newSnapshots = append(newSnapshots, historySnapshot{
+			Timestamp: now,
+			Repo:      r.RepoSpec,
+			Release:   r.Name,
+			Downloads: r.Downloads,
+		})
+	}
+
+	h.Snapshots = append(h.Snapshots, newSnapshots...)
+	return h.save(path)
+}
+
+// pruneSnapshots drops every snapshot at or before cutoff, keeping the store bounded
+// across a long-running --serve daemon's lifetime.
+func pruneSnapshots(snapshots []historySnapshot, cutoff time.Time) []historySnapshot {
+	out := snapshots[:0]
+	for _, s := range snapshots {
+		if s.Timestamp.After(cutoff) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// downloadsPerDayBetween computes the downloads/day rate between two consecutive
+// snapshots: the change in total downloads divided by the days between them. It reports
+// false if the snapshots aren't far enough apart to produce a meaningful rate.
+func downloadsPerDayBetween(prev, cur historySnapshot) (HistoryPoint, bool) {
+	days := cur.Timestamp.Sub(prev.Timestamp).Hours() / 24
+	if days <= 0 {
+		return HistoryPoint{}, false
+	}
+
+	var delta int
+	for asset, count := range cur.Downloads {
+		delta += count - prev.Downloads[asset]
+	}
+
+	return HistoryPoint{Date: cur.Timestamp, DownloadsPerDay: float64(delta) / days}, true
+}
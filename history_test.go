@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDownloadsPerDayBetween(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	prev := historySnapshot{Timestamp: t0, Downloads: map[string]int{"a": 100, "b": 50}}
+	cur := historySnapshot{Timestamp: t0.Add(48 * time.Hour), Downloads: map[string]int{"a": 148, "b": 50}}
+
+	pt, ok := downloadsPerDayBetween(prev, cur)
+	if !ok {
+		t.Fatal("expected a valid rate for two days apart")
+	}
+	if pt.DownloadsPerDay != 24 {
+		t.Errorf("DownloadsPerDay = %v, want 24 (48 downloads / 2 days)", pt.DownloadsPerDay)
+	}
+	if !pt.Date.Equal(cur.Timestamp) {
+		t.Errorf("Date = %v, want %v", pt.Date, cur.Timestamp)
+	}
+}
+
+func TestDownloadsPerDayBetweenNonPositiveInterval(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	same := historySnapshot{Timestamp: t0, Downloads: map[string]int{"a": 10}}
+
+	if _, ok := downloadsPerDayBetween(same, same); ok {
+		t.Error("expected no rate for two snapshots at the same timestamp")
+	}
+}
+
+func TestHistoryColumn(t *testing.T) {
+	a := &release{RepoSpec: "github:org/one", Name: "v1.0.0"}
+	b := &release{RepoSpec: "github:org/two", Name: "v1.0.0"}
+
+	if historyColumn(a) == historyColumn(b) {
+		t.Errorf("expected distinct columns for same tag name across different repos, got %q for both", historyColumn(a))
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Renderer turns a set of gathered releases, plus the derived contributor activity, into
+// output for a particular presentation. Selected via --format.
+type Renderer interface {
+	Render(repo string, rs []*release, weeks []ContributorWeek, logins []string) (string, error)
+}
+
+// renderers maps each --format value to its Renderer.
+var renderers = map[string]Renderer{
+	"html":    htmlRenderer{},
+	"json":    jsonRenderer{},
+	"csv":     csvRenderer{},
+	"prom":    prometheusRenderer{},
+	"echarts": echartsRenderer{},
+}
+
+// htmlRenderer renders the Google Charts dashboard in renderHTML.
+type htmlRenderer struct{}
+
+func (htmlRenderer) Render(repo string, rs []*release, weeks []ContributorWeek, logins []string) (string, error) {
+	return renderHTML(repo, rs, weeks, logins)
+}
+
+// jsonRenderer dumps every gathered release and its asset stats as indented JSON.
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(repo string, rs []*release, weeks []ContributorWeek, logins []string) (string, error) {
+	out := struct {
+		Repo              string            `json:"repo"`
+		Releases          []*release        `json:"releases"`
+		ContributorWeeks  []ContributorWeek `json:"contributor_weeks,omitempty"`
+		ContributorLogins []string          `json:"contributor_logins,omitempty"`
+	}{
+		Repo:              repo,
+		Releases:          rs,
+		ContributorWeeks:  weeks,
+		ContributorLogins: logins,
+	}
+
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal: %w", err)
+	}
+	return string(b), nil
+}
+
+// csvRenderer emits one row per release/asset, for spreadsheet analysis.
+type csvRenderer struct{}
+
+func (csvRenderer) Render(repo string, rs []*release, _ []ContributorWeek, _ []string) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"repo", "release", "source", "published_at", "asset", "downloads"}); err != nil {
+		return "", err
+	}
+
+	for _, r := range rs {
+		repoLabel := repoLabel(repo, r)
+		for asset, count := range r.Downloads {
+			row := []string{repoLabel, r.Name, r.Source, r.PublishedAt.Format(time.RFC3339), asset, strconv.Itoa(count)}
+			if err := w.Write(row); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// prometheusRenderer emits github_release_downloads_total gauges in the Prometheus
+// exposition format, suitable for scraping directly or via --listen.
+type prometheusRenderer struct{}
+
+func (prometheusRenderer) Render(repo string, rs []*release, _ []ContributorWeek, _ []string) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "# HELP github_release_downloads_total Downloads for a release asset.")
+	fmt.Fprintln(&buf, "# TYPE github_release_downloads_total gauge")
+
+	for _, r := range rs {
+		repoLabel := repoLabel(repo, r)
+		for asset, count := range r.Downloads {
+			fmt.Fprintf(&buf, "github_release_downloads_total{repo=%q,release=%q,asset=%q} %d\n", repoLabel, r.Name, asset, count)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// repoLabel returns the repo spec a release was gathered from, falling back to the
+// overlay title when it isn't set (e.g. for releases built outside gatherAndRender).
+func repoLabel(fallback string, r *release) string {
+	if r.RepoSpec != "" {
+		return r.RepoSpec
+	}
+	return fallback
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// serve runs gatherAndRender once, then again every interval, serving the most recently
+// rendered output at addr until the process exits.
+func serve(ctx context.Context, addr string, interval time.Duration) {
+	serveRoute(ctx, addr, "/", interval, "")
+}
+
+// serveMetrics runs gatherAndRender (expected to be used with --format=prom) once, then
+// again every interval, exposing the most recently rendered metrics at addr/metrics.
+func serveMetrics(ctx context.Context, addr string, interval time.Duration) {
+	serveRoute(ctx, addr, "/metrics", interval, "text/plain; version=0.0.4")
+}
+
+// serveRoute refreshes gatherAndRender's output on a schedule and serves the most recent
+// result at path until the process exits.
+func serveRoute(ctx context.Context, addr string, path string, interval time.Duration, contentType string) {
+	var mu sync.RWMutex
+	var cached string
+
+	refresh := func() {
+		out, err := gatherAndRender(ctx)
+		if err != nil {
+			klog.Errorf("refresh failed: %v", err)
+			return
+		}
+
+		mu.Lock()
+		cached = out
+		mu.Unlock()
+	}
+
+	refresh()
+	go func() {
+		for range time.Tick(interval) {
+			refresh()
+		}
+	}()
+
+	http.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		mu.RLock()
+		defer mu.RUnlock()
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		fmt.Fprint(w, cached)
+	})
+
+	klog.Infof("serving %s on %s, refreshing every %s", path, addr, interval)
+	klog.Exit(http.ListenAndServe(addr, nil))
+}
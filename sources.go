@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// readTokenFile returns the trimmed contents of path, or "" if path is empty.
+// Non-GitHub sources treat an empty token path as "unauthenticated".
+func readTokenFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// getJSON issues an HTTP GET against url with the given headers and decodes the JSON response into out.
+func getJSON(ctx context.Context, url string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// ReleaseSource fetches releases for a project hosted on a particular platform.
+type ReleaseSource interface {
+	// Releases returns the processed releases for spec, the portion of a --repo
+	// value following the source prefix (e.g. "owner/repo", or for Gitea
+	// "host/owner/repo"). Each source parses spec in whatever shape it needs.
+	Releases(ctx context.Context, spec string) ([]*release, error)
+}
+
+// sourceFactories maps a --repo prefix to the constructor for its ReleaseSource.
+// "github" has no prefix and is the default when a --repo flag is unprefixed.
+var sourceFactories = map[string]func(ctx context.Context) (ReleaseSource, error){
+	"github":    newGitHubSource,
+	"gitlab":    newGitLabSource,
+	"gitea":     newGiteaSource,
+	"dockerhub": newDockerHubSource,
+}
+
+// sourceIcons gives a short glyph per source, rendered next to release names.
+var sourceIcons = map[string]string{
+	"github":    "ⓖ", // Ⓖ
+	"gitlab":    "\U0001F98A",
+	"gitea":     "\U0001F375",
+	"dockerhub": "\U0001F433",
+}
+
+// splitSourcePrefix splits a --repo value such as "gitlab:owner/repo" into its
+// source prefix and the remaining repo identifier. Unprefixed values (and
+// anything not matching a known prefix) are treated as "github".
+func splitSourcePrefix(spec string) (prefix string, rest string) {
+	if i := strings.Index(spec, ":"); i > 0 {
+		if _, ok := sourceFactories[spec[:i]]; ok {
+			return spec[:i], spec[i+1:]
+		}
+	}
+	return "github", spec
+}
+
+// newReleaseSource constructs the ReleaseSource registered for prefix.
+func newReleaseSource(ctx context.Context, prefix string) (ReleaseSource, error) {
+	factory, ok := sourceFactories[prefix]
+	if !ok {
+		return nil, fmt.Errorf("unknown source %q", prefix)
+	}
+	return factory(ctx)
+}
+
+// sourceIcon returns the glyph for a source name, falling back to the name itself.
+func sourceIcon(source string) string {
+	if icon, ok := sourceIcons[source]; ok {
+		return icon
+	}
+	return source
+}
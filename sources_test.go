@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestSplitSourcePrefix(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantPrefix string
+		wantRest   string
+	}{
+		{"kubernetes/kubernetes", "github", "kubernetes/kubernetes"},
+		{"gitlab:gitlab-org/gitlab", "gitlab", "gitlab-org/gitlab"},
+		{"gitea:codeberg.org/forgejo/forgejo", "gitea", "codeberg.org/forgejo/forgejo"},
+		{"dockerhub:library/alpine", "dockerhub", "library/alpine"},
+		{"unknownsource:foo/bar", "github", "unknownsource:foo/bar"},
+		{"gitlab:foo:bar", "gitlab", "foo:bar"},
+	}
+
+	for _, tt := range tests {
+		prefix, rest := splitSourcePrefix(tt.spec)
+		if prefix != tt.wantPrefix || rest != tt.wantRest {
+			t.Errorf("splitSourcePrefix(%q) = (%q, %q), want (%q, %q)", tt.spec, prefix, rest, tt.wantPrefix, tt.wantRest)
+		}
+	}
+}